@@ -0,0 +1,218 @@
+// Package discovery is a thin, cache-backed client around Eureka so
+// order-api and product-api can look each other up instead of hard-coding
+// hosts and ports.
+package discovery
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hudl/fargo"
+)
+
+// refreshInterval is the periodic fallback poll used in case a push update
+// from ScheduleAppUpdates is missed.
+const refreshInterval = 30 * time.Second
+
+// Instance is a resolved, healthy service endpoint.
+type Instance struct {
+	HostName string
+	Port     int
+	IPAddr   string
+	Status   string
+	// Zone is the instance's availability zone, when Eureka reports one
+	// (e.g. for Amazon-hosted instances). It is empty for MyOwn datacenters.
+	Zone string
+}
+
+// BaseURL returns the instance's http base URL.
+func (i Instance) BaseURL() string {
+	return fmt.Sprintf("http://%s:%d", i.HostName, i.Port)
+}
+
+// Client registers a local instance with Eureka and resolves other
+// services' instances from a locally cached, push-updated view of the
+// registry.
+type Client interface {
+	Register() error
+	Deregister() error
+	Heartbeat() error
+
+	// Instances returns the cached, healthy (UP) instances for app.
+	Instances(app string) ([]Instance, error)
+
+	// Next resolves app and round-robins across its healthy instances, so
+	// repeated calls spread load evenly.
+	Next(app string) (Instance, error)
+
+	// Subscribe seeds the returned channel with the current instance list
+	// for app and pushes a fresh list every time Eureka reports a change.
+	// The returned func stops the subscription.
+	Subscribe(app string) (<-chan []Instance, func())
+}
+
+type eurekaClient struct {
+	conn     *fargo.EurekaConnection
+	instance *fargo.Instance
+
+	mu      sync.RWMutex
+	cache   map[string][]Instance
+	counter map[string]*uint64
+}
+
+// NewClient builds a Client that registers instance against conn.
+func NewClient(conn *fargo.EurekaConnection, instance *fargo.Instance) Client {
+	return &eurekaClient{
+		conn:     conn,
+		instance: instance,
+		cache:    make(map[string][]Instance),
+		counter:  make(map[string]*uint64),
+	}
+}
+
+func (c *eurekaClient) Register() error {
+	return c.conn.RegisterInstance(c.instance)
+}
+
+func (c *eurekaClient) Deregister() error {
+	return c.conn.DeregisterInstance(c.instance)
+}
+
+func (c *eurekaClient) Heartbeat() error {
+	return c.conn.HeartBeatInstance(c.instance)
+}
+
+func (c *eurekaClient) Instances(app string) ([]Instance, error) {
+	c.mu.RLock()
+	cached, ok := c.cache[app]
+	c.mu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	instances, err := c.fetch(app)
+	if err != nil {
+		return nil, err
+	}
+	c.store(app, instances)
+	return instances, nil
+}
+
+func (c *eurekaClient) Next(app string) (Instance, error) {
+	instances, err := c.Instances(app)
+	if err != nil {
+		return Instance{}, err
+	}
+	if len(instances) == 0 {
+		return Instance{}, fmt.Errorf("discovery: no healthy instances for %s", app)
+	}
+
+	c.mu.Lock()
+	counter, ok := c.counter[app]
+	if !ok {
+		counter = new(uint64)
+		c.counter[app] = counter
+	}
+	c.mu.Unlock()
+
+	idx := atomic.AddUint64(counter, 1) - 1
+	return instances[idx%uint64(len(instances))], nil
+}
+
+func (c *eurekaClient) Subscribe(app string) (<-chan []Instance, func()) {
+	updates := make(chan []Instance, 1)
+	done := make(chan struct{})
+
+	if instances, err := c.fetch(app); err == nil {
+		c.store(app, instances)
+		updates <- instances
+	} else {
+		log.Printf("discovery: initial resolve of %s failed: %v", app, err)
+	}
+
+	appUpdates := c.conn.ScheduleAppUpdates(app, false, done)
+
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case update, ok := <-appUpdates:
+				if !ok {
+					return
+				}
+				if update.Err != nil {
+					log.Printf("discovery: update for %s failed: %v", app, update.Err)
+					continue
+				}
+				instances := healthyInstances(update.App)
+				c.store(app, instances)
+				select {
+				case updates <- instances:
+				default:
+				}
+			case <-ticker.C:
+				instances, err := c.fetch(app)
+				if err != nil {
+					log.Printf("discovery: periodic refresh of %s failed: %v", app, err)
+					continue
+				}
+				c.store(app, instances)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	cancel := func() { once.Do(func() { close(done) }) }
+	return updates, cancel
+}
+
+func (c *eurekaClient) fetch(app string) ([]Instance, error) {
+	application, err := c.conn.GetApp(app)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: resolve %s: %w", app, err)
+	}
+	return healthyInstances(application), nil
+}
+
+func (c *eurekaClient) store(app string, instances []Instance) {
+	c.mu.Lock()
+	c.cache[app] = instances
+	c.mu.Unlock()
+}
+
+func healthyInstances(app *fargo.Application) []Instance {
+	if app == nil {
+		return nil
+	}
+	instances := make([]Instance, 0, len(app.Instances))
+	for _, inst := range app.Instances {
+		if inst.Status != fargo.UP {
+			continue
+		}
+		instances = append(instances, Instance{
+			HostName: inst.HostName,
+			Port:     inst.Port,
+			IPAddr:   inst.IPAddr,
+			Status:   string(inst.Status),
+			Zone:     zoneOf(inst),
+		})
+	}
+	return instances
+}
+
+// zoneOf extracts the availability zone from an instance's datacenter
+// metadata, if any. Instances registered with fargo.MyOwn (as both
+// order-api and product-api do) have no such metadata.
+func zoneOf(inst *fargo.Instance) string {
+	if inst.DataCenterInfo.Name != fargo.Amazon {
+		return ""
+	}
+	return inst.DataCenterInfo.Metadata.AvailabilityZone
+}
@@ -0,0 +1,33 @@
+package eventing
+
+import (
+	"context"
+	"log"
+)
+
+// Consumer reads events off a Queue and fans each one out to every
+// Publisher, so downstream systems can subscribe without the HTTP request
+// path blocking on them.
+type Consumer struct {
+	queue      Queue
+	publishers []Publisher
+}
+
+// NewConsumer builds a Consumer reading from queue and fanning out to
+// publishers.
+func NewConsumer(queue Queue, publishers ...Publisher) *Consumer {
+	return &Consumer{queue: queue, publishers: publishers}
+}
+
+// Run ranges over the queue's events until it is closed and drained. It is
+// meant to be run in its own goroutine; it returns once there is nothing
+// left to deliver.
+func (c *Consumer) Run() {
+	for event := range c.queue.Events() {
+		for _, publisher := range c.publishers {
+			if err := publisher.Publish(context.Background(), event); err != nil {
+				log.Printf("eventing: publish %s order %s failed: %v", event.Type, event.Order.ID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package eventing
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+// recordingPublisher records every event it's asked to publish.
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []OrderEvent
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event OrderEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *recordingPublisher) recorded() []OrderEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]OrderEvent(nil), p.events...)
+}
+
+func TestConsumerRunFansOutToEveryPublisher(t *testing.T) {
+	queue := NewMemoryQueue(4)
+	pub1, pub2 := &recordingPublisher{}, &recordingPublisher{}
+	consumer := NewConsumer(queue, pub1, pub2)
+
+	done := make(chan struct{})
+	go func() {
+		consumer.Run()
+		close(done)
+	}()
+
+	events := []OrderEvent{
+		{Type: EventCreated, Order: Order{ID: "o1"}},
+		{Type: EventUpdated, Order: Order{ID: "o1"}},
+	}
+	for _, event := range events {
+		if err := queue.Enqueue(context.Background(), event); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	if err := queue.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	<-done
+
+	for _, pub := range []*recordingPublisher{pub1, pub2} {
+		got := pub.recorded()
+		if len(got) != len(events) {
+			t.Fatalf("publisher recorded %d events, want %d", len(got), len(events))
+		}
+		for i, event := range events {
+			if got[i] != event {
+				t.Fatalf("publisher event %d = %+v, want %+v", i, got[i], event)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Package eventing decouples order-api's request path from downstream
+// systems (inventory, email, ...) by enqueueing an OrderEvent for every
+// mutation and fanning it out to one or more Publishers from a background
+// Consumer, instead of calling those systems inline.
+package eventing
+
+import (
+	"context"
+	"time"
+)
+
+// Order is the wire representation of an order carried on an OrderEvent. It
+// mirrors order-api's own Order type without importing it, since order-api
+// imports this package rather than the other way around.
+type Order struct {
+	ID      string  `json:"id"`
+	Total   float64 `json:"total"`
+	Product string  `json:"product"`
+}
+
+// Event types for OrderEvent.Type.
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// OrderEvent records a single order mutation.
+type OrderEvent struct {
+	Type      string    `json:"type"`
+	Order     Order     `json:"order"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Queue decouples producers (order-api's handlers) from consumers (the
+// Consumer below) of OrderEvents.
+type Queue interface {
+	// Enqueue hands event to the queue, blocking until it is accepted or
+	// ctx is done.
+	Enqueue(ctx context.Context, event OrderEvent) error
+	// Events returns the channel a Consumer ranges over. It is closed once
+	// the queue is closed and fully drained.
+	Events() <-chan OrderEvent
+	// Close stops accepting new events and, once any in-flight ones are
+	// delivered, closes the channel returned by Events.
+	Close() error
+}
+
+// Publisher fans an OrderEvent out to a downstream system.
+type Publisher interface {
+	Publish(ctx context.Context, event OrderEvent) error
+}
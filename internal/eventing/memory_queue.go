@@ -0,0 +1,39 @@
+package eventing
+
+import "context"
+
+// defaultMemoryQueueBuffer bounds how many events can sit unread before
+// Enqueue starts blocking.
+const defaultMemoryQueueBuffer = 64
+
+// MemoryQueue is an in-process, channel-backed Queue for tests and local
+// dev, where running a real broker isn't worth the overhead.
+type MemoryQueue struct {
+	events chan OrderEvent
+}
+
+// NewMemoryQueue builds a MemoryQueue with the given channel buffer size.
+func NewMemoryQueue(buffer int) *MemoryQueue {
+	if buffer <= 0 {
+		buffer = defaultMemoryQueueBuffer
+	}
+	return &MemoryQueue{events: make(chan OrderEvent, buffer)}
+}
+
+func (q *MemoryQueue) Enqueue(ctx context.Context, event OrderEvent) error {
+	select {
+	case q.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *MemoryQueue) Events() <-chan OrderEvent {
+	return q.events
+}
+
+func (q *MemoryQueue) Close() error {
+	close(q.events)
+	return nil
+}
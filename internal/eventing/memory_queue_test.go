@@ -0,0 +1,53 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryQueueEnqueueAndDrain(t *testing.T) {
+	q := NewMemoryQueue(2)
+
+	want := OrderEvent{Type: EventCreated, Order: Order{ID: "o1", Total: 9.99, Product: "Widget"}}
+	if err := q.Enqueue(context.Background(), want); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	select {
+	case got := <-q.Events():
+		if got != want {
+			t.Fatalf("Events() = %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for enqueued event")
+	}
+}
+
+func TestMemoryQueueEnqueueRespectsContext(t *testing.T) {
+	q := NewMemoryQueue(1)
+	if err := q.Enqueue(context.Background(), OrderEvent{}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The buffer is already full, so Enqueue must block until ctx is done
+	// rather than hang forever.
+	if err := q.Enqueue(ctx, OrderEvent{}); err != ctx.Err() {
+		t.Fatalf("Enqueue with done ctx = %v, want %v", err, ctx.Err())
+	}
+}
+
+func TestMemoryQueueCloseClosesEvents(t *testing.T) {
+	q := NewMemoryQueue(1)
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	_, ok := <-q.Events()
+	if ok {
+		t.Fatal("Events() channel should be closed after Close")
+	}
+}
@@ -0,0 +1,60 @@
+package eventing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// StdoutPublisher logs every event to stdout as JSON. It never fails, and
+// is the simplest way to observe the pipeline locally.
+type StdoutPublisher struct{}
+
+func (StdoutPublisher) Publish(_ context.Context, event OrderEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("eventing: marshal order event: %w", err)
+	}
+	log.Println(string(body))
+	return nil
+}
+
+// WebhookPublisher POSTs every event as JSON to a configured URL.
+type WebhookPublisher struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookPublisher builds a WebhookPublisher posting to url with a
+// sensible request timeout.
+func NewWebhookPublisher(url string) *WebhookPublisher {
+	return &WebhookPublisher{URL: url, Client: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event OrderEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("eventing: marshal order event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("eventing: build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("eventing: webhook post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("eventing: webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package eventing
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// NewQueueFromEnv selects a Queue implementation from EVENT_QUEUE_KIND
+// (memory|sqs, default memory). When sqs is selected, SQS_QUEUE_URL must
+// also be set.
+func NewQueueFromEnv(ctx context.Context) (Queue, error) {
+	switch kind := os.Getenv("EVENT_QUEUE_KIND"); kind {
+	case "", "memory":
+		return NewMemoryQueue(defaultMemoryQueueBuffer), nil
+	case "sqs":
+		queueURL := os.Getenv("SQS_QUEUE_URL")
+		if queueURL == "" {
+			return nil, fmt.Errorf("eventing: SQS_QUEUE_URL is required when EVENT_QUEUE_KIND=sqs")
+		}
+		return NewSQSQueue(ctx, queueURL)
+	default:
+		return nil, fmt.Errorf("eventing: unknown EVENT_QUEUE_KIND %q", kind)
+	}
+}
@@ -0,0 +1,112 @@
+package eventing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// SQSQueue is a Queue backed by an AWS SQS queue, for when order-api runs
+// with more than one instance behind Eureka and events need to survive a
+// restart.
+type SQSQueue struct {
+	client   *sqs.Client
+	queueURL string
+	events   chan OrderEvent
+	done     chan struct{}
+}
+
+// NewSQSQueue builds an SQSQueue against queueURL, using the default AWS
+// credential chain, and starts polling for messages in the background.
+func NewSQSQueue(ctx context.Context, queueURL string) (*SQSQueue, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("eventing: load aws config: %w", err)
+	}
+
+	q := &SQSQueue{
+		client:   sqs.NewFromConfig(cfg),
+		queueURL: queueURL,
+		events:   make(chan OrderEvent),
+		done:     make(chan struct{}),
+	}
+	go q.poll()
+	return q, nil
+}
+
+func (q *SQSQueue) Enqueue(ctx context.Context, event OrderEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("eventing: marshal order event: %w", err)
+	}
+
+	_, err = q.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	if err != nil {
+		return fmt.Errorf("eventing: sqs send message: %w", err)
+	}
+	return nil
+}
+
+func (q *SQSQueue) Events() <-chan OrderEvent {
+	return q.events
+}
+
+func (q *SQSQueue) Close() error {
+	close(q.done)
+	return nil
+}
+
+// poll long-polls SQS for messages, forwards each to Events, and deletes it
+// once forwarded. It exits once Close is called.
+func (q *SQSQueue) poll() {
+	defer close(q.events)
+
+	ctx := context.Background()
+	for {
+		select {
+		case <-q.done:
+			return
+		default:
+		}
+
+		out, err := q.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(q.queueURL),
+			MaxNumberOfMessages: 10,
+			WaitTimeSeconds:     10,
+		})
+		if err != nil {
+			log.Printf("eventing: sqs receive failed: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, msg := range out.Messages {
+			var event OrderEvent
+			if err := json.Unmarshal([]byte(aws.ToString(msg.Body)), &event); err != nil {
+				log.Printf("eventing: discarding malformed sqs message: %v", err)
+			} else {
+				select {
+				case q.events <- event:
+				case <-q.done:
+					return
+				}
+			}
+
+			if _, err := q.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(q.queueURL),
+				ReceiptHandle: msg.ReceiptHandle,
+			}); err != nil {
+				log.Printf("eventing: sqs delete message failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// MemoryRepository is an in-process OrderRepository backed by a map. It
+// loses all data on restart and can't be shared across instances, but is
+// the simplest option for tests and local dev.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	orders map[string]Order
+	nextID int
+}
+
+// NewMemoryRepository builds a MemoryRepository seeded with the same demo
+// orders order-api has always shipped with.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		orders: map[string]Order{
+			"o101": {ID: "o101", Total: 1200.50, Product: "Laptop"},
+			"o102": {ID: "o102", Total: 25.00, Product: "Mouse"},
+		},
+		nextID: 3,
+	}
+}
+
+func (r *MemoryRepository) Get(_ context.Context, id string) (Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, ok := r.orders[id]
+	if !ok {
+		return Order{}, ErrNotFound
+	}
+	return order, nil
+}
+
+func (r *MemoryRepository) List(_ context.Context) ([]Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orders := make([]Order, 0, len(r.orders))
+	for _, order := range r.orders {
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *MemoryRepository) Create(_ context.Context, order Order) (Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order.ID = fmt.Sprintf("o1%02d", r.nextID)
+	r.nextID++
+	r.orders[order.ID] = order
+	return order, nil
+}
+
+func (r *MemoryRepository) Update(_ context.Context, order Order) (Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[order.ID]; !ok {
+		return Order{}, ErrNotFound
+	}
+	r.orders[order.ID] = order
+	return order, nil
+}
+
+func (r *MemoryRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.orders, id)
+	return nil
+}
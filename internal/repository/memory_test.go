@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMemoryRepositorySeedsDemoOrders(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	orders, err := repo.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("List returned %d orders, want 2", len(orders))
+	}
+
+	got, err := repo.Get(context.Background(), "o101")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := Order{ID: "o101", Total: 1200.50, Product: "Laptop"}
+	if got != want {
+		t.Fatalf("Get(o101) = %+v, want %+v", got, want)
+	}
+}
+
+func TestMemoryRepositoryCreateAssignsID(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	created, err := repo.Create(context.Background(), Order{Total: 9.99, Product: "Widget"})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	got, err := repo.Get(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", created.ID, err)
+	}
+	if got != created {
+		t.Fatalf("Get(%s) = %+v, want %+v", created.ID, got, created)
+	}
+}
+
+func TestMemoryRepositoryUpdate(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	updated, err := repo.Update(context.Background(), Order{ID: "o101", Total: 999, Product: "Laptop Pro"})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if updated.Total != 999 || updated.Product != "Laptop Pro" {
+		t.Fatalf("Update returned %+v, want updated fields applied", updated)
+	}
+
+	got, err := repo.Get(context.Background(), "o101")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got != updated {
+		t.Fatalf("Get(o101) after update = %+v, want %+v", got, updated)
+	}
+}
+
+func TestMemoryRepositoryUpdateNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	_, err := repo.Update(context.Background(), Order{ID: "missing"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Update(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryRepositoryDelete(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if err := repo.Delete(context.Background(), "o101"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	_, err := repo.Get(context.Background(), "o101")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryRepositoryDeleteNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	err := repo.Delete(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryRepositoryGetNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	_, err := repo.Get(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+}
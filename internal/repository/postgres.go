@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+)
+
+// schema bootstraps the orders table and the sequence used to generate
+// order IDs, so multiple ORDER-SERVICE instances registered to the same
+// Eureka VIP don't collide on the same ID.
+const schema = `
+CREATE TABLE IF NOT EXISTS orders (
+	id      TEXT PRIMARY KEY,
+	total   DOUBLE PRECISION NOT NULL,
+	product TEXT NOT NULL
+);
+
+CREATE SEQUENCE IF NOT EXISTS orders_id_seq;
+`
+
+// PostgresRepository is an OrderRepository backed by Postgres.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRepository opens databaseURL and bootstraps the orders schema.
+func NewPostgresRepository(databaseURL string) (*PostgresRepository, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("repository: open postgres: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("repository: ping postgres: %w", err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("repository: bootstrap schema: %w", err)
+	}
+
+	return &PostgresRepository{db: db}, nil
+}
+
+func (r *PostgresRepository) Get(ctx context.Context, id string) (Order, error) {
+	var order Order
+	err := r.db.QueryRowContext(ctx, `SELECT id, total, product FROM orders WHERE id = $1`, id).
+		Scan(&order.ID, &order.Total, &order.Product)
+	if err == sql.ErrNoRows {
+		return Order{}, ErrNotFound
+	}
+	if err != nil {
+		return Order{}, fmt.Errorf("repository: get order %s: %w", id, err)
+	}
+	return order, nil
+}
+
+func (r *PostgresRepository) List(ctx context.Context) ([]Order, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, total, product FROM orders`)
+	if err != nil {
+		return nil, fmt.Errorf("repository: list orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []Order
+	for rows.Next() {
+		var order Order
+		if err := rows.Scan(&order.ID, &order.Total, &order.Product); err != nil {
+			return nil, fmt.Errorf("repository: scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func (r *PostgresRepository) Create(ctx context.Context, order Order) (Order, error) {
+	var seq int64
+	if err := r.db.QueryRowContext(ctx, `SELECT nextval('orders_id_seq')`).Scan(&seq); err != nil {
+		return Order{}, fmt.Errorf("repository: next order id: %w", err)
+	}
+	order.ID = fmt.Sprintf("o1%02d", seq)
+
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO orders (id, total, product) VALUES ($1, $2, $3)`,
+		order.ID, order.Total, order.Product)
+	if err != nil {
+		return Order{}, fmt.Errorf("repository: create order: %w", err)
+	}
+	return order, nil
+}
+
+func (r *PostgresRepository) Update(ctx context.Context, order Order) (Order, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE orders SET total = $2, product = $3 WHERE id = $1`,
+		order.ID, order.Total, order.Product)
+	if err != nil {
+		return Order{}, fmt.Errorf("repository: update order %s: %w", order.ID, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return Order{}, ErrNotFound
+	}
+	return order, nil
+}
+
+func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM orders WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("repository: delete order %s: %w", id, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
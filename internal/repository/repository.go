@@ -0,0 +1,35 @@
+// Package repository persists orders behind an OrderRepository interface,
+// so order-api can run against an in-memory map for tests and local dev or
+// a shared Postgres database once it needs to survive restarts and scale
+// horizontally behind Eureka.
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// Order is the persisted representation of an order. It mirrors order-api's
+// own Order type without importing it, since order-api imports this
+// package rather than the other way around.
+type Order struct {
+	ID      string
+	Total   float64
+	Product string
+}
+
+// ErrNotFound is returned by Get, Update and Delete when no order with the
+// given ID exists.
+var ErrNotFound = errors.New("repository: order not found")
+
+// OrderRepository persists orders independent of the backing store.
+// Create assigns the ID itself (callers leave Order.ID blank) since ID
+// generation is backend-specific: MemoryRepository uses an in-process
+// counter, PostgresRepository a DB sequence.
+type OrderRepository interface {
+	Get(ctx context.Context, id string) (Order, error)
+	List(ctx context.Context) ([]Order, error)
+	Create(ctx context.Context, order Order) (Order, error)
+	Update(ctx context.Context, order Order) (Order, error)
+	Delete(ctx context.Context, id string) error
+}
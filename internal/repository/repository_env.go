@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewFromEnv selects an OrderRepository from ORDER_STORE (memory|postgres,
+// default memory). DATABASE_URL is required when ORDER_STORE=postgres.
+func NewFromEnv() (OrderRepository, error) {
+	switch store := os.Getenv("ORDER_STORE"); store {
+	case "", "memory":
+		return NewMemoryRepository(), nil
+	case "postgres":
+		databaseURL := os.Getenv("DATABASE_URL")
+		if databaseURL == "" {
+			return nil, fmt.Errorf("repository: DATABASE_URL is required when ORDER_STORE=postgres")
+		}
+		return NewPostgresRepository(databaseURL)
+	default:
+		return nil, fmt.Errorf("repository: unknown ORDER_STORE %q", store)
+	}
+}
@@ -1,78 +1,100 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/hudl/fargo"
+
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/internal/discovery"
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/internal/eventing"
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/internal/repository"
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/pkg/httpclient"
 )
 
+// defaultDeregisterGrace matches the Netflix Eureka client's renewal
+// interval, giving gateway-side caches time to expire this instance before
+// it's actually gone.
+const defaultDeregisterGrace = 30 * time.Second
+
+const defaultShutdownTimeout = 10 * time.Second
+
 type Order struct {
 	ID      string  `json:"id"`
 	Total   float64 `json:"total"`
 	Product string  `json:"product"`
 }
 
-var (
-	ordersStore = make(map[string]Order)
-	ordersMutex = &sync.RWMutex{}
-	nextOrderID = 3
-)
+// EnrichedOrder augments an Order with details resolved from PRODUCT-SERVICE.
+type EnrichedOrder struct {
+	Order
+	ProductID string `json:"product_id,omitempty"`
+}
 
-func init() {
-	ordersStore["o101"] = Order{ID: "o101", Total: 1200.50, Product: "Laptop"}
-	ordersStore["o102"] = Order{ID: "o102", Total: 25.00, Product: "Mouse"}
+// remoteProduct mirrors product-api's Product as returned by GET /products.
+type remoteProduct struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
-func ordersRouter(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-
-	switch r.Method {
-	case http.MethodGet:
-		handleGetOrders(w, r)
-	case http.MethodPost:
-		handleCreateOrder(w, r)
-	case http.MethodPut:
-		handleUpdateOrder(w, r)
-	case http.MethodDelete:
-		handleDeleteOrder(w, r)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+func ordersRouter(repo repository.OrderRepository, remoteClient *httpclient.Client, queue eventing.Queue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/enriched") {
+			handleGetEnrichedOrder(repo, remoteClient, w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			handleGetOrders(repo, w, r)
+		case http.MethodPost:
+			handleCreateOrder(repo, queue, w, r)
+		case http.MethodPut:
+			handleUpdateOrder(repo, queue, w, r)
+		case http.MethodDelete:
+			handleDeleteOrder(repo, queue, w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
 	}
 }
 
-func handleGetOrders(w http.ResponseWriter, r *http.Request) {
+func handleGetOrders(repo repository.OrderRepository, w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/orders/")
 
-	ordersMutex.RLock()
-	defer ordersMutex.RUnlock()
-
 	if id != "" {
-		order, found := ordersStore[id]
-		if !found {
+		order, err := repo.Get(r.Context(), id)
+		if err != nil {
 			http.Error(w, `{"error": "Order not found"}`, http.StatusNotFound)
 			return
 		}
-		json.NewEncoder(w).Encode(order)
+		json.NewEncoder(w).Encode(toOrder(order))
 	} else {
-		allOrders := make([]Order, 0, len(ordersStore))
-		for _, order := range ordersStore {
-			allOrders = append(allOrders, order)
+		orders, err := repo.List(r.Context())
+		if err != nil {
+			http.Error(w, `{"error": "Failed to list orders"}`, http.StatusInternalServerError)
+			return
+		}
+		allOrders := make([]Order, 0, len(orders))
+		for _, order := range orders {
+			allOrders = append(allOrders, toOrder(order))
 		}
 		json.NewEncoder(w).Encode(allOrders)
 	}
 	log.Println("GET /orders request served by order-api")
 }
 
-func handleCreateOrder(w http.ResponseWriter, r *http.Request) {
+func handleCreateOrder(repo repository.OrderRepository, queue eventing.Queue, w http.ResponseWriter, r *http.Request) {
 	var newOrder Order
 	err := json.NewDecoder(r.Body).Decode(&newOrder)
 	if err != nil {
@@ -80,68 +102,136 @@ func handleCreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ordersMutex.Lock()
-	defer ordersMutex.Unlock()
+	created, err := repo.Create(r.Context(), fromOrder(newOrder))
+	if err != nil {
+		http.Error(w, `{"error": "Failed to create order"}`, http.StatusInternalServerError)
+		return
+	}
 
-	newOrder.ID = fmt.Sprintf("o1%02d", nextOrderID)
-	nextOrderID++
-	ordersStore[newOrder.ID] = newOrder
+	publishOrderEvent(queue, eventing.EventCreated, created)
 
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(newOrder)
-	log.Printf("POST /orders request served, created order %s\n", newOrder.ID)
+	json.NewEncoder(w).Encode(toOrder(created))
+	log.Printf("POST /orders request served, created order %s\n", created.ID)
 }
 
-func handleUpdateOrder(w http.ResponseWriter, r *http.Request) {
+func handleUpdateOrder(repo repository.OrderRepository, queue eventing.Queue, w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/orders/")
 	if id == "" {
 		http.Error(w, `{"error": "Order ID is required"}`, http.StatusBadRequest)
 		return
 	}
 
-	ordersMutex.Lock()
-	defer ordersMutex.Unlock()
+	var updatedOrder Order
+	if err := json.NewDecoder(r.Body).Decode(&updatedOrder); err != nil {
+		http.Error(w, `{"error": "Invalid request body"}`, http.StatusBadRequest)
+		return
+	}
+	updatedOrder.ID = id
 
-	_, found := ordersStore[id]
-	if !found {
+	updated, err := repo.Update(r.Context(), fromOrder(updatedOrder))
+	if err == repository.ErrNotFound {
 		http.Error(w, `{"error": "Order not found"}`, http.StatusNotFound)
 		return
 	}
-
-	var updatedOrder Order
-	err := json.NewDecoder(r.Body).Decode(&updatedOrder)
 	if err != nil {
-		http.Error(w, `{"error": "Invalid request body"}`, http.StatusBadRequest)
+		http.Error(w, `{"error": "Failed to update order"}`, http.StatusInternalServerError)
 		return
 	}
 
-	updatedOrder.ID = id
-	ordersStore[id] = updatedOrder
+	publishOrderEvent(queue, eventing.EventUpdated, updated)
 
-	json.NewEncoder(w).Encode(updatedOrder)
+	json.NewEncoder(w).Encode(toOrder(updated))
 	log.Printf("PUT /orders request served, updated order %s\n", id)
 }
 
-func handleDeleteOrder(w http.ResponseWriter, r *http.Request) {
+func handleDeleteOrder(repo repository.OrderRepository, queue eventing.Queue, w http.ResponseWriter, r *http.Request) {
 	id := strings.TrimPrefix(r.URL.Path, "/orders/")
 	if id == "" {
 		http.Error(w, `{"error": "Order ID is required"}`, http.StatusBadRequest)
 		return
 	}
 
-	ordersMutex.Lock()
-	defer ordersMutex.Unlock()
-
-	if _, found := ordersStore[id]; !found {
+	deleted, err := repo.Get(r.Context(), id)
+	if err != nil {
 		http.Error(w, `{"error": "Order not found"}`, http.StatusNotFound)
 		return
 	}
 
-	delete(ordersStore, id)
+	if err := repo.Delete(r.Context(), id); err != nil {
+		http.Error(w, `{"error": "Failed to delete order"}`, http.StatusInternalServerError)
+		return
+	}
+
+	publishOrderEvent(queue, eventing.EventDeleted, deleted)
+
 	w.WriteHeader(http.StatusNoContent)
 	log.Printf("DELETE /orders request served, deleted order %s\n", id)
 }
 
+// publishOrderEvent enqueues an OrderEvent for order after the mutation has
+// already been committed to repo, so the HTTP response never waits on
+// downstream consumers.
+func publishOrderEvent(queue eventing.Queue, eventType string, order repository.Order) {
+	event := eventing.OrderEvent{
+		Type:      eventType,
+		Order:     eventing.Order{ID: order.ID, Total: order.Total, Product: order.Product},
+		Timestamp: time.Now(),
+	}
+	if err := queue.Enqueue(context.Background(), event); err != nil {
+		log.Printf("eventing: enqueue %s event for order %s failed: %v", eventType, order.ID, err)
+	}
+}
+
+// handleGetEnrichedOrder resolves PRODUCT-SERVICE through remoteClient and
+// inlines the canonical product ID alongside the order.
+func handleGetEnrichedOrder(repo repository.OrderRepository, remoteClient *httpclient.Client, w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/orders/"), "/enriched")
+
+	order, err := repo.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, `{"error": "Order not found"}`, http.StatusNotFound)
+		return
+	}
+
+	enriched := EnrichedOrder{Order: toOrder(order)}
+
+	var products []remoteProduct
+	if err := remoteClient.GetJSON(r.Context(), "PRODUCT-SERVICE", "/products", &products); err != nil {
+		log.Printf("enriched order %s: fetch products from PRODUCT-SERVICE: %v", id, err)
+		json.NewEncoder(w).Encode(enriched)
+		return
+	}
+
+	for _, p := range products {
+		if p.Name == order.Product {
+			enriched.ProductID = p.ID
+			break
+		}
+	}
+
+	json.NewEncoder(w).Encode(enriched)
+	log.Printf("GET /orders/%s/enriched request served by order-api\n", id)
+}
+
+// deregisterGrace returns the EUREKA_DEREGISTER_GRACE duration, or
+// defaultDeregisterGrace if unset or invalid.
+func deregisterGrace() time.Duration {
+	if v := os.Getenv("EUREKA_DEREGISTER_GRACE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultDeregisterGrace
+}
+
+func toOrder(order repository.Order) Order {
+	return Order{ID: order.ID, Total: order.Total, Product: order.Product}
+}
+
+func fromOrder(order Order) repository.Order {
+	return repository.Order{ID: order.ID, Total: order.Total, Product: order.Product}
+}
 
 func main() {
 	eurekaConn := fargo.NewConn("http://localhost:8761/eureka")
@@ -157,40 +247,100 @@ func main() {
 		HealthCheckUrl:   "http://localhost:9092/health",
 	}
 
-	err := eurekaConn.RegisterInstance(instance)
-	if err != nil {
+	discoveryClient := discovery.NewClient(&eurekaConn, instance)
+
+	if err := discoveryClient.Register(); err != nil {
 		log.Printf("Eureka registration failed: %v", err)
 	} else {
 		log.Println("Successfully registered with Eureka as ORDER-SERVICE")
 	}
 
+	heartbeatCtx, stopHeartbeat := context.WithCancel(context.Background())
 	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
 		for {
-			err := eurekaConn.HeartBeatInstance(instance)
-			if err != nil {
-				log.Printf("Eureka lease renewal (heartbeat) failed: %v. Re-registering...", err)
-				_ = eurekaConn.RegisterInstance(instance)
+			select {
+			case <-ticker.C:
+				if err := discoveryClient.Heartbeat(); err != nil {
+					log.Printf("Eureka lease renewal (heartbeat) failed: %v. Re-registering...", err)
+					_ = discoveryClient.Register()
+				}
+			case <-heartbeatCtx.Done():
+				return
 			}
-			time.Sleep(30 * time.Second)
 		}
 	}()
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	// Keep the PRODUCT-SERVICE instance cache warm for the enriched-order
+	// endpoint, via push updates with a 30s polling fallback.
+	_, stopProductSubscription := discoveryClient.Subscribe("PRODUCT-SERVICE")
+
+	repo, err := repository.NewFromEnv()
+	if err != nil {
+		log.Fatalf("repository: %v", err)
+	}
+
+	queue, err := eventing.NewQueueFromEnv(context.Background())
+	if err != nil {
+		log.Fatalf("eventing: %v", err)
+	}
+
+	publishers := []eventing.Publisher{eventing.StdoutPublisher{}}
+	if webhookURL := os.Getenv("ORDER_EVENTS_WEBHOOK_URL"); webhookURL != "" {
+		publishers = append(publishers, eventing.NewWebhookPublisher(webhookURL))
+	}
+	consumer := eventing.NewConsumer(queue, publishers...)
+	consumerDone := make(chan struct{})
 	go func() {
-		<-c
-		log.Println("De-registering from Eureka...")
-		_ = eurekaConn.DeregisterInstance(instance)
-		log.Println("Shutting down Go Order API.")
-		os.Exit(0)
+		consumer.Run()
+		close(consumerDone)
 	}()
 
-	http.HandleFunc("/orders/", ordersRouter)
-	http.HandleFunc("/orders", ordersRouter)
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
-	
-	log.Println("Go Order API (CRUD) starting on port 9092...")
-	if err := http.ListenAndServe(":9092", nil); err != nil {
-		log.Fatal(err)
+	remoteClient := httpclient.New(discoveryClient, httpclient.Config{
+		LocalZone: os.Getenv("EUREKA_ZONE"),
+	})
+
+	mux := http.NewServeMux()
+	router := ordersRouter(repo, remoteClient, queue)
+	mux.HandleFunc("/orders/", router)
+	mux.HandleFunc("/orders", router)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	server := &http.Server{Addr: ":9092", Handler: mux}
+
+	go func() {
+		log.Println("Go Order API (CRUD) starting on port 9092...")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	// Stop heartbeating before deregistering, or a tick landing during the
+	// grace sleep below would re-register (or renew the lease on) the
+	// instance we're trying to let expire.
+	stopHeartbeat()
+
+	log.Println("De-registering from Eureka...")
+	_ = discoveryClient.Deregister()
+
+	grace := deregisterGrace()
+	log.Printf("Waiting %s for Eureka clients to expire this instance...", grace)
+	time.Sleep(grace)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown: %v", err)
 	}
-}
\ No newline at end of file
+
+	log.Println("Draining order events...")
+	_ = queue.Close()
+	<-consumerDone
+
+	stopProductSubscription()
+	log.Println("Shutting down Go Order API.")
+}
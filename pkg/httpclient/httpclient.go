@@ -0,0 +1,248 @@
+// Package httpclient is a resilient outbound HTTP client for calls between
+// services registered in Eureka. It resolves candidate instances through
+// discovery.Client, spreads load across them in shuffled, zone-preferring
+// order, and quarantines endpoints that fail with a network error or 5xx
+// response behind an exponential backoff before they become eligible again.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/internal/discovery"
+)
+
+// Config tunes the retry and quarantine behavior of a Client.
+type Config struct {
+	// MaxAttempts is the number of distinct instances to try before giving
+	// up. Defaults to 3.
+	MaxAttempts int
+	// AttemptTimeout bounds a single instance's round trip. Defaults to 2s.
+	AttemptTimeout time.Duration
+	// LocalZone, when set, is tried before instances in any other zone.
+	LocalZone string
+	// QuarantineFor is the initial cool-down applied to a failing endpoint;
+	// it backs off exponentially on repeated failures. Defaults to 5s.
+	QuarantineFor time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	if c.AttemptTimeout <= 0 {
+		c.AttemptTimeout = 2 * time.Second
+	}
+	if c.QuarantineFor <= 0 {
+		c.QuarantineFor = 5 * time.Second
+	}
+	return c
+}
+
+// Client issues HTTP requests against instances of a discovered service.
+type Client struct {
+	discovery discovery.Client
+	http      *http.Client
+	cfg       Config
+
+	mu          sync.Mutex
+	quarantined map[string]time.Time
+	backoffs    map[string]backoff.BackOff
+
+	retries     uint64
+	quarantines uint64
+}
+
+// New builds a Client that resolves instances through discoveryClient.
+func New(discoveryClient discovery.Client, cfg Config) *Client {
+	return &Client{
+		discovery:   discoveryClient,
+		http:        &http.Client{},
+		cfg:         cfg.withDefaults(),
+		quarantined: make(map[string]time.Time),
+		backoffs:    make(map[string]backoff.BackOff),
+	}
+}
+
+// Retries returns the number of failed attempts observed so far.
+func (c *Client) Retries() uint64 { return atomic.LoadUint64(&c.retries) }
+
+// Quarantines returns the number of times an endpoint has been quarantined.
+func (c *Client) Quarantines() uint64 { return atomic.LoadUint64(&c.quarantines) }
+
+// Do resolves serviceName through discovery and replays req against
+// candidate instances, in shuffled and zone-preferring order, until one
+// succeeds or MaxAttempts is exhausted. req's URL host/scheme are
+// overwritten per attempt; only its path, method, headers and body matter.
+func (c *Client) Do(ctx context.Context, serviceName string, req *http.Request) (*http.Response, error) {
+	instances, err := c.discovery.Instances(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("httpclient: resolve %s: %w", serviceName, err)
+	}
+
+	candidates := c.order(c.eligible(instances))
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("httpclient: no eligible instances for %s", serviceName)
+	}
+
+	// Prefer discovery's round-robin pick as the first attempt, so load
+	// still spreads evenly across healthy instances when none are
+	// quarantined; the zone-preferring shuffle above governs retry order
+	// for the rest.
+	if primary, err := c.discovery.Next(serviceName); err == nil {
+		candidates = prioritize(candidates, primary)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, fmt.Errorf("httpclient: read request body: %w", err)
+		}
+		req.Body.Close()
+	}
+
+	attempts := c.cfg.MaxAttempts
+	if attempts > len(candidates) {
+		attempts = len(candidates)
+	}
+
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		instance := candidates[i]
+
+		attemptCtx, cancel := context.WithTimeout(ctx, c.cfg.AttemptTimeout)
+		attemptReq := req.Clone(attemptCtx)
+		attemptReq.URL.Scheme = "http"
+		attemptReq.URL.Host = fmt.Sprintf("%s:%d", instance.HostName, instance.Port)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, doErr := c.http.Do(attemptReq)
+		cancel()
+
+		if doErr == nil && resp.StatusCode < 500 {
+			return resp, nil
+		}
+
+		if doErr == nil {
+			doErr = fmt.Errorf("got %s", resp.Status)
+			resp.Body.Close()
+		}
+
+		lastErr = doErr
+		key := instanceKey(instance)
+		c.quarantine(key)
+		atomic.AddUint64(&c.retries, 1)
+		log.Printf("httpclient: attempt %d/%d to %s (%s) failed: %v", i+1, attempts, serviceName, key, doErr)
+	}
+
+	return nil, fmt.Errorf("httpclient: all attempts to %s failed: %w", serviceName, lastErr)
+}
+
+// GetJSON issues a GET for path against serviceName and decodes the JSON
+// response body into out.
+func (c *Client) GetJSON(ctx context.Context, serviceName, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+serviceName+path, nil)
+	if err != nil {
+		return fmt.Errorf("httpclient: build request for %s%s: %w", serviceName, path, err)
+	}
+
+	resp, err := c.Do(ctx, serviceName, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("httpclient: %s%s returned %s", serviceName, path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func instanceKey(i discovery.Instance) string {
+	return fmt.Sprintf("%s:%d", i.HostName, i.Port)
+}
+
+// eligible filters out instances that are still in quarantine.
+func (c *Client) eligible(instances []discovery.Instance) []discovery.Instance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	eligible := make([]discovery.Instance, 0, len(instances))
+	for _, instance := range instances {
+		until, quarantined := c.quarantined[instanceKey(instance)]
+		if quarantined && time.Now().Before(until) {
+			continue
+		}
+		eligible = append(eligible, instance)
+	}
+	return eligible
+}
+
+// order shuffles instances, placing any in the configured local zone ahead
+// of the rest.
+func (c *Client) order(instances []discovery.Instance) []discovery.Instance {
+	local := make([]discovery.Instance, 0, len(instances))
+	remote := make([]discovery.Instance, 0, len(instances))
+	for _, instance := range instances {
+		if c.cfg.LocalZone != "" && instance.Zone == c.cfg.LocalZone {
+			local = append(local, instance)
+		} else {
+			remote = append(remote, instance)
+		}
+	}
+	rand.Shuffle(len(local), func(i, j int) { local[i], local[j] = local[j], local[i] })
+	rand.Shuffle(len(remote), func(i, j int) { remote[i], remote[j] = remote[j], remote[i] })
+	return append(local, remote...)
+}
+
+// prioritize moves primary to the front of candidates, if present, leaving
+// the rest in their existing order.
+func prioritize(candidates []discovery.Instance, primary discovery.Instance) []discovery.Instance {
+	for i, instance := range candidates {
+		if instance == primary {
+			if i == 0 {
+				return candidates
+			}
+			reordered := make([]discovery.Instance, 0, len(candidates))
+			reordered = append(reordered, instance)
+			reordered = append(reordered, candidates[:i]...)
+			reordered = append(reordered, candidates[i+1:]...)
+			return reordered
+		}
+	}
+	return candidates
+}
+
+// quarantine moves key into cool-down for an exponential-backoff interval
+// that grows on each consecutive failure.
+func (c *Client) quarantine(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.backoffs[key]
+	if !ok {
+		eb := backoff.NewExponentialBackOff()
+		eb.InitialInterval = c.cfg.QuarantineFor
+		eb.MaxElapsedTime = 0
+		b = eb
+		c.backoffs[key] = b
+	}
+
+	until := time.Now().Add(b.NextBackOff())
+	c.quarantined[key] = until
+	atomic.AddUint64(&c.quarantines, 1)
+	log.Printf("httpclient: quarantining %s until %s", key, until.Format(time.RFC3339))
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -10,8 +12,17 @@ import (
 	"time"
 
 	"github.com/hudl/fargo"
+
+	"github.com/thanthtooaung-coding/spring-cloud-gateway-go/internal/discovery"
 )
 
+// defaultDeregisterGrace matches the Netflix Eureka client's renewal
+// interval, giving gateway-side caches time to expire this instance before
+// it's actually gone.
+const defaultDeregisterGrace = 30 * time.Second
+
+const defaultShutdownTimeout = 10 * time.Second
+
 type Product struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -27,6 +38,17 @@ func productsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Println("Request to /products served by product-api")
 }
 
+// deregisterGrace returns the EUREKA_DEREGISTER_GRACE duration, or
+// defaultDeregisterGrace if unset or invalid.
+func deregisterGrace() time.Duration {
+	if v := os.Getenv("EUREKA_DEREGISTER_GRACE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultDeregisterGrace
+}
+
 func main() {
 	eurekaConn := fargo.NewConn("http://localhost:8761/eureka")
 	instance := &fargo.Instance{
@@ -41,38 +63,64 @@ func main() {
 		HealthCheckUrl:   "http://localhost:9091/health",
 	}
 
-	err := eurekaConn.RegisterInstance(instance)
-	if err != nil {
+	discoveryClient := discovery.NewClient(&eurekaConn, instance)
+
+	if err := discoveryClient.Register(); err != nil {
 		log.Printf("Eureka registration failed: %v", err)
 	} else {
 		log.Println("Successfully registered with Eureka as PRODUCT-SERVICE")
 	}
-	
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(context.Background())
 	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
 		for {
-			err := eurekaConn.HeartBeatInstance(instance)
-			if err != nil {
-				log.Printf("Eureka lease renewal (heartbeat) failed: %v. Re-registering...", err)
-				_ = eurekaConn.RegisterInstance(instance)
+			select {
+			case <-ticker.C:
+				if err := discoveryClient.Heartbeat(); err != nil {
+					log.Printf("Eureka lease renewal (heartbeat) failed: %v. Re-registering...", err)
+					_ = discoveryClient.Register()
+				}
+			case <-heartbeatCtx.Done():
+				return
 			}
-			time.Sleep(30 * time.Second)
 		}
 	}()
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/products", productsHandler)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	server := &http.Server{Addr: ":9091", Handler: mux}
+
 	go func() {
-		<-c
-		log.Println("De-registering from Eureka...")
-		_ = eurekaConn.DeregisterInstance(instance)
-		log.Println("Shutting down Go Product API.")
-		os.Exit(0)
+		log.Println("Go Product API starting on port 9091...")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
 	}()
 
-	http.HandleFunc("/products", productsHandler)
-	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
-	log.Println("Go Product API starting on port 9091...")
-	if err := http.ListenAndServe(":9091", nil); err != nil {
-		log.Fatal(err)
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	<-c
+
+	// Stop heartbeating before deregistering, or a tick landing during the
+	// grace sleep below would re-register (or renew the lease on) the
+	// instance we're trying to let expire.
+	stopHeartbeat()
+
+	log.Println("De-registering from Eureka...")
+	_ = discoveryClient.Deregister()
+
+	grace := deregisterGrace()
+	log.Printf("Waiting %s for Eureka clients to expire this instance...", grace)
+	time.Sleep(grace)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("HTTP server shutdown: %v", err)
 	}
-}
\ No newline at end of file
+
+	log.Println("Shutting down Go Product API.")
+}